@@ -2,170 +2,316 @@ package cache
 
 import (
 	"fmt"
+	"runtime"
 	"sync"
 	"time"
 )
 
-type unexportedInterface interface {
-	Set(string, string, time.Duration)
-	Add(string, string, time.Duration) error
-	Replace(string, string, time.Duration) error
-	Get(string) (string, bool)
+// NoExpiration is used with functions that take an expiration time to signal
+// that the item never expires.
+const NoExpiration time.Duration = -1
+
+type unexportedInterface[V any] interface {
+	Set(string, V, time.Duration)
+	Add(string, V, time.Duration) error
+	Replace(string, V, time.Duration) error
+	Get(string) (V, bool)
 	Delete(string)
 	DeleteExpired()
 	ItemCount() int
 }
 
-type item struct {
-	Object     string
-	Expiration *time.Time
+type item[V any] struct {
+	Object     V
+	Expiration int64
 }
 
 // Returns true if the item has expired.
-func (i *item) Expired() bool {
-	if i.Expiration == nil {
+func (i *item[V]) Expired() bool {
+	if i.Expiration == 0 {
 		return false
 	}
-	return i.Expiration.Before(time.Now())
+	return time.Now().UnixNano() > i.Expiration
 }
 
-type Cache struct {
-	*cache
-	// If this is confusing, see the comment at the bottom of New()
+type Cache[V any] struct {
+	*cache[V]
 }
 
-type cache struct {
+type cache[V any] struct {
 	sync.RWMutex
 	defaultExpiration time.Duration
-	items             map[string]*item
-	consumerChannel   chan []string
+	items             map[string]*item[V]
+	janitor           *janitor[V]
+	onEvicted         func(string, V)
+}
+
+// OnEvicted sets a function to call when an item is evicted from the cache,
+// either by Delete, by TTL expiry in the janitor, or by being overwritten in
+// Set or Replace. It is called outside of the cache's lock, so it may safely
+// call back into the cache, and it replaces any function set previously.
+func (c *cache[V]) OnEvicted(f func(string, V)) {
+	c.Lock()
+	c.onEvicted = f
+	c.Unlock()
 }
 
 // Add an item to the cache, replacing any existing item. If the duration is 0,
-// the cache's default expiration time is used. If it is -1, the item never
-// expires.
-func (c *cache) Set(k string, x string, d time.Duration) {
-	c.consumerChannel <- []string{"set", k, x}
+// the cache's default expiration time is used. If it is -1 (NoExpiration),
+// the item never expires.
+func (c *cache[V]) Set(k string, x V, d time.Duration) {
+	c.Lock()
+	ov, evicted := c.set(k, x, d)
+	c.Unlock()
+	if evicted {
+		c.onEvicted(k, ov)
+	}
 }
 
-func (c *cache) set(k string, x string, d time.Duration) {
-	var e *time.Time
+// set stores x under k and returns the value it replaced, if any. It skips
+// the lookup for a replaced value entirely when no eviction callback is
+// registered, since the caller has no use for it in that case.
+func (c *cache[V]) set(k string, x V, d time.Duration) (V, bool) {
+	var e int64
 	if d == 0 {
 		d = c.defaultExpiration
 	}
 	if d > 0 {
-		t := time.Now().Add(d)
-		e = &t
+		e = time.Now().Add(d).UnixNano()
+	}
+	var ov V
+	var evicted bool
+	if c.onEvicted != nil {
+		if old, found := c.items[k]; found {
+			ov, evicted = old.Object, true
+		}
 	}
-	c.items[k] = &item{
+	c.items[k] = &item[V]{
 		Object:     x,
 		Expiration: e,
 	}
+	return ov, evicted
 }
 
 // Add an item to the cache only if an item doesn't already exist for the given
 // key, or if the existing item has expired. Returns an error otherwise.
-func (c *cache) Add(k string, x string, d time.Duration) error {
+func (c *cache[V]) Add(k string, x V, d time.Duration) error {
+	c.Lock()
 	_, found := c.get(k)
 	if found {
+		c.Unlock()
 		return fmt.Errorf("Item %s already exists", k)
 	}
-	c.Set(k, x, d)
+	c.set(k, x, d)
+	c.Unlock()
 	return nil
 }
 
 // Set a new value for the cache key only if it already exists, and the existing
 // item hasn't expired. Returns an error otherwise.
-func (c *cache) Replace(k string, x string, d time.Duration) error {
+func (c *cache[V]) Replace(k string, x V, d time.Duration) error {
+	c.Lock()
 	_, found := c.get(k)
 	if !found {
+		c.Unlock()
 		return fmt.Errorf("Item %s doesn't exist", k)
 	}
-	c.Set(k, x, d)
+	ov, evicted := c.set(k, x, d)
+	c.Unlock()
+	if evicted {
+		c.onEvicted(k, ov)
+	}
 	return nil
 }
 
-// Get an item from the cache. Returns the item or nil, and a bool indicating
-// whether the key was found.
-func (c *cache) Get(k string) (string, bool) {
-	x, found := c.get(k)
-	return x, found
+// Get an item from the cache. Returns the item or the zero value of V, and a
+// bool indicating whether the key was found. The returned value is copied out
+// of the stored item rather than handed back as a pointer, so callers never
+// force it onto the heap. A read lock is held only long enough to copy the
+// item; expired items are left for DeleteExpired rather than upgrading to a
+// write lock on every Get.
+func (c *cache[V]) Get(k string) (V, bool) {
+	c.RLock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		c.RUnlock()
+		var zero V
+		return zero, false
+	}
+	x := it.Object
+	c.RUnlock()
+	return x, true
 }
 
-func (c *cache) get(k string) (string, bool) {
-	item, found := c.items[k]
-	if !found || item.Expired() {
-		c.Delete(k)
-		return "", false
+// GetWithExpiration returns an item and its expiration time from the cache.
+// It returns the item or the zero value of V, the expiration time if one is
+// set (the zero time.Time otherwise), and a bool indicating whether the key
+// was found.
+func (c *cache[V]) GetWithExpiration(k string) (V, time.Time, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		var zero V
+		return zero, time.Time{}, false
+	}
+	if it.Expiration > 0 {
+		return it.Object, time.Unix(0, it.Expiration), true
 	}
-	return item.Object, true
+	return it.Object, time.Time{}, true
+}
+
+func (c *cache[V]) get(k string) (V, bool) {
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		var zero V
+		return zero, false
+	}
+	return it.Object, true
 }
 
 // Delete an item from the cache. Does nothing if the key is not in the cache.
-func (c *cache) Delete(k string) {
-	c.consumerChannel <- []string{"delete", k, ""}
+func (c *cache[V]) Delete(k string) {
+	c.Lock()
+	ov, evicted := c.delete(k)
+	c.Unlock()
+	if evicted {
+		c.onEvicted(k, ov)
+	}
 }
 
-func (c *cache) delete(k string) {
+// delete removes k and, only when an eviction callback is registered, returns
+// the value it held so the caller can invoke the callback once unlocked.
+func (c *cache[V]) delete(k string) (V, bool) {
+	if c.onEvicted != nil {
+		if v, found := c.items[k]; found {
+			delete(c.items, k)
+			return v.Object, true
+		}
+	}
 	delete(c.items, k)
+	var zero V
+	return zero, false
+}
+
+type evictedItem[V any] struct {
+	key   string
+	value V
 }
 
 // Delete all expired items from the cache.
-func (c *cache) DeleteExpired() {
+func (c *cache[V]) DeleteExpired() {
+	var evictedItems []evictedItem[V]
+	now := time.Now().UnixNano()
+	c.Lock()
 	for k, v := range c.items {
-		if v.Expired() {
-			c.consumerChannel <- []string{"delete", k, ""}
+		if v.Expiration > 0 && now > v.Expiration {
+			ov, evicted := c.delete(k)
+			if evicted {
+				evictedItems = append(evictedItems, evictedItem[V]{k, ov})
+			}
 		}
 	}
+	c.Unlock()
+	for _, e := range evictedItems {
+		c.onEvicted(e.key, e.value)
+	}
 }
 
 // Returns the number of items in the cache. This may include items that have
 // expired, but have not yet been cleaned up.
-func (c *cache) ItemCount() int {
+func (c *cache[V]) ItemCount() int {
+	c.RLock()
 	n := len(c.items)
+	c.RUnlock()
 	return n
 }
 
-func newCache(de time.Duration) *cache {
+// Items returns a copy of all unexpired items in the cache, keyed by their
+// cache key. The copy is made under lock, so it's safe to hand to callers
+// (for serialization or metrics, for example) without exposing the internal
+// map to concurrent mutation.
+func (c *cache[V]) Items() map[string]item[V] {
+	c.RLock()
+	defer c.RUnlock()
+	m := make(map[string]item[V], len(c.items))
+	now := time.Now().UnixNano()
+	for k, v := range c.items {
+		if v.Expiration > 0 && now > v.Expiration {
+			continue
+		}
+		m[k] = *v
+	}
+	return m
+}
+
+// Flush deletes all items from the cache, without running the eviction
+// callback for any of them.
+func (c *cache[V]) Flush() {
+	c.Lock()
+	c.items = map[string]*item[V]{}
+	c.Unlock()
+}
+
+func newCache[V any](de time.Duration) *cache[V] {
 	if de == 0 {
 		de = -1
 	}
-	c := &cache{
+	c := &cache[V]{
 		defaultExpiration: de,
-		items:             map[string]*item{},
+		items:             map[string]*item[V]{},
 	}
 	return c
 }
 
-// Return a new cache with a given default expiration duration.
-// If the expiration duration is less than 1, the items in the cache
-// never expire (by default), and must be deleted manually.
-func New(defaultExpiration time.Duration) *Cache {
-	c := newCache(defaultExpiration)
-	// This trick ensures that the consumer goroutine (which--granted it
-	// was enabled--is running DeleteExpired on c forever) does not keep
-	// the returned C object from being garbage collected. When it is
-	// garbage collected, the finalizer stops the consumer goroutine, after
-	// which c can be collected.
-	C := &Cache{c}
-	c.consumerChannel = make(chan []string)
-
-	go runConsumer(c)
-	return C
+type janitor[V any] struct {
+	interval time.Duration
+	stop     chan bool
 }
 
-func runConsumer(c *cache) {
+func (j *janitor[V]) Run(c *cache[V]) {
+	ticker := time.NewTicker(j.interval)
 	for {
 		select {
-		case v := <- c.consumerChannel:
-			operation := v[0]
-			key := v[1]
-			val := v[2]
-			if operation == "set" {
-				c.set(key, val, c.defaultExpiration)
-			} else if operation == "delete" {
-				c.delete(key)
-			}
+		case <-ticker.C:
+			c.DeleteExpired()
+		case <-j.stop:
+			ticker.Stop()
+			return
 		}
 	}
 }
+
+func stopJanitor[V any](c *Cache[V]) {
+	c.janitor.stop <- true
+}
+
+func runJanitor[V any](c *cache[V], ci time.Duration) {
+	j := &janitor[V]{
+		interval: ci,
+		stop:     make(chan bool),
+	}
+	c.janitor = j
+	go j.Run(c)
+}
+
+// Return a new cache with a given default expiration duration and cleanup
+// interval. If the expiration duration is less than 1 (or NoExpiration), the
+// items in the cache never expire (by default), and must be deleted
+// manually. If the cleanup interval is less than or equal to 0, expired
+// items are not cleaned up automatically and must be purged with
+// DeleteExpired, matching the previous behavior.
+func New[V any](defaultExpiration, cleanupInterval time.Duration) *Cache[V] {
+	c := newCache[V](defaultExpiration)
+	// This trick ensures that the janitor goroutine (which--granted it
+	// was started--is running DeleteExpired on c forever) does not keep
+	// the returned C object from being garbage collected. When it is
+	// garbage collected, the finalizer stops the janitor goroutine, after
+	// which c can be collected.
+	C := &Cache[V]{c}
+	if cleanupInterval > 0 {
+		runJanitor(c, cleanupInterval)
+		runtime.SetFinalizer(C, stopJanitor[V])
+	}
+	return C
+}
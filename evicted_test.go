@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOnEvictedByTTL(t *testing.T) {
+	var fired int32
+
+	tc := New[string](0, 1*time.Millisecond)
+	tc.OnEvicted(func(k string, v string) {
+		atomic.AddInt32(&fired, 1)
+	})
+	tc.Set("foo", "bar", 20*time.Millisecond)
+
+	<-time.After(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&fired); n != 1 {
+		t.Errorf("OnEvicted fired %d times, expected 1", n)
+	}
+}
+
+func TestOnEvictedByDelete(t *testing.T) {
+	var fired int32
+
+	tc := New[string](0, 0)
+	tc.OnEvicted(func(k string, v string) {
+		atomic.AddInt32(&fired, 1)
+	})
+	tc.Set("foo", "bar", NoExpiration)
+	tc.Delete("foo")
+
+	if n := atomic.LoadInt32(&fired); n != 1 {
+		t.Errorf("OnEvicted fired %d times, expected 1", n)
+	}
+
+	// Deleting an absent key must not fire the callback again.
+	tc.Delete("foo")
+	if n := atomic.LoadInt32(&fired); n != 1 {
+		t.Errorf("OnEvicted fired %d times after deleting an absent key, expected 1", n)
+	}
+}
+
+func TestOnEvictedBySetOverwrite(t *testing.T) {
+	var fired int32
+
+	tc := New[string](0, 0)
+	tc.Set("foo", "bar", NoExpiration)
+	tc.OnEvicted(func(k string, v string) {
+		atomic.AddInt32(&fired, 1)
+	})
+	tc.Set("foo", "baz", NoExpiration)
+
+	if n := atomic.LoadInt32(&fired); n != 1 {
+		t.Errorf("OnEvicted fired %d times, expected 1", n)
+	}
+}
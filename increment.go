@@ -0,0 +1,576 @@
+package cache
+
+import "fmt"
+
+// Increment an item of type int, int8, int16, int32, int64, uint, uintptr,
+// uint8, uint32, uint64, float32, or float64 by n. Returns an error if the
+// item's value is not one of these types, if it was not found, or if it has
+// expired. If there is no error, the item's value is incremented by n in
+// place and the entire operation is performed under a single lock, so
+// concurrent Increments don't lose updates.
+func (c *cache[V]) Increment(k string, n int64) error {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return fmt.Errorf("Item %s not found", k)
+	}
+	switch v := any(it.Object).(type) {
+	case int:
+		it.Object = any(v + int(n)).(V)
+	case int8:
+		it.Object = any(v + int8(n)).(V)
+	case int16:
+		it.Object = any(v + int16(n)).(V)
+	case int32:
+		it.Object = any(v + int32(n)).(V)
+	case int64:
+		it.Object = any(v + n).(V)
+	case uint:
+		it.Object = any(v + uint(n)).(V)
+	case uintptr:
+		it.Object = any(v + uintptr(n)).(V)
+	case uint8:
+		it.Object = any(v + uint8(n)).(V)
+	case uint16:
+		it.Object = any(v + uint16(n)).(V)
+	case uint32:
+		it.Object = any(v + uint32(n)).(V)
+	case uint64:
+		it.Object = any(v + uint64(n)).(V)
+	case float32:
+		it.Object = any(v + float32(n)).(V)
+	case float64:
+		it.Object = any(v + float64(n)).(V)
+	default:
+		return fmt.Errorf("The value for %s does not have a numeric type that Increment supports", k)
+	}
+	return nil
+}
+
+// IncrementFloat increments an item of type float32 or float64 by n.
+// Returns an error if the item's value is not one of these types, if it was
+// not found, or if it has expired.
+func (c *cache[V]) IncrementFloat(k string, n float64) error {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return fmt.Errorf("Item %s not found", k)
+	}
+	switch v := any(it.Object).(type) {
+	case float32:
+		it.Object = any(v + float32(n)).(V)
+	case float64:
+		it.Object = any(v + n).(V)
+	default:
+		return fmt.Errorf("The value for %s does not have a floating-point type that IncrementFloat supports", k)
+	}
+	return nil
+}
+
+// IncrementInt increments an item of type int by n, returning the new value.
+func (c *cache[V]) IncrementInt(k string, n int) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementInt8 increments an item of type int8 by n, returning the new value.
+func (c *cache[V]) IncrementInt8(k string, n int8) (int8, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int8)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int8", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementInt16 increments an item of type int16 by n, returning the new value.
+func (c *cache[V]) IncrementInt16(k string, n int16) (int16, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int16)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int16", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementInt32 increments an item of type int32 by n, returning the new value.
+func (c *cache[V]) IncrementInt32(k string, n int32) (int32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int32", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementInt64 increments an item of type int64 by n, returning the new value.
+func (c *cache[V]) IncrementInt64(k string, n int64) (int64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int64", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUint increments an item of type uint by n, returning the new value.
+func (c *cache[V]) IncrementUint(k string, n uint) (uint, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUintptr increments an item of type uintptr by n, returning the new value.
+func (c *cache[V]) IncrementUintptr(k string, n uintptr) (uintptr, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uintptr)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uintptr", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUint8 increments an item of type uint8 by n, returning the new value.
+func (c *cache[V]) IncrementUint8(k string, n uint8) (uint8, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint8)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint8", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUint16 increments an item of type uint16 by n, returning the new value.
+func (c *cache[V]) IncrementUint16(k string, n uint16) (uint16, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint16)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint16", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUint32 increments an item of type uint32 by n, returning the new value.
+func (c *cache[V]) IncrementUint32(k string, n uint32) (uint32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint32", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementUint64 increments an item of type uint64 by n, returning the new value.
+func (c *cache[V]) IncrementUint64(k string, n uint64) (uint64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint64", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementFloat32 increments an item of type float32 by n, returning the new value.
+func (c *cache[V]) IncrementFloat32(k string, n float32) (float32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(float32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a float32", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// IncrementFloat64 increments an item of type float64 by n, returning the new value.
+func (c *cache[V]) IncrementFloat64(k string, n float64) (float64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(float64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a float64", k)
+	}
+	nv := rv + n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// Decrement an item of type int, int8, int16, int32, int64, uint, uintptr,
+// uint8, uint32, uint64, float32, or float64 by n. Returns an error if the
+// item's value is not one of these types, if it was not found, or if it has
+// expired. To subtract a non-negative number, use Increment with a negative
+// n instead.
+func (c *cache[V]) Decrement(k string, n int64) error {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return fmt.Errorf("Item %s not found", k)
+	}
+	switch v := any(it.Object).(type) {
+	case int:
+		it.Object = any(v - int(n)).(V)
+	case int8:
+		it.Object = any(v - int8(n)).(V)
+	case int16:
+		it.Object = any(v - int16(n)).(V)
+	case int32:
+		it.Object = any(v - int32(n)).(V)
+	case int64:
+		it.Object = any(v - n).(V)
+	case uint:
+		it.Object = any(v - uint(n)).(V)
+	case uintptr:
+		it.Object = any(v - uintptr(n)).(V)
+	case uint8:
+		it.Object = any(v - uint8(n)).(V)
+	case uint16:
+		it.Object = any(v - uint16(n)).(V)
+	case uint32:
+		it.Object = any(v - uint32(n)).(V)
+	case uint64:
+		it.Object = any(v - uint64(n)).(V)
+	case float32:
+		it.Object = any(v - float32(n)).(V)
+	case float64:
+		it.Object = any(v - float64(n)).(V)
+	default:
+		return fmt.Errorf("The value for %s does not have a numeric type that Decrement supports", k)
+	}
+	return nil
+}
+
+// DecrementFloat decrements an item of type float32 or float64 by n.
+func (c *cache[V]) DecrementFloat(k string, n float64) error {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return fmt.Errorf("Item %s not found", k)
+	}
+	switch v := any(it.Object).(type) {
+	case float32:
+		it.Object = any(v - float32(n)).(V)
+	case float64:
+		it.Object = any(v - n).(V)
+	default:
+		return fmt.Errorf("The value for %s does not have a floating-point type that DecrementFloat supports", k)
+	}
+	return nil
+}
+
+// DecrementInt decrements an item of type int by n, returning the new value.
+func (c *cache[V]) DecrementInt(k string, n int) (int, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementInt8 decrements an item of type int8 by n, returning the new value.
+func (c *cache[V]) DecrementInt8(k string, n int8) (int8, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int8)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int8", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementInt16 decrements an item of type int16 by n, returning the new value.
+func (c *cache[V]) DecrementInt16(k string, n int16) (int16, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int16)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int16", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementInt32 decrements an item of type int32 by n, returning the new value.
+func (c *cache[V]) DecrementInt32(k string, n int32) (int32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int32", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementInt64 decrements an item of type int64 by n, returning the new value.
+func (c *cache[V]) DecrementInt64(k string, n int64) (int64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(int64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not an int64", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUint decrements an item of type uint by n, returning the new value.
+func (c *cache[V]) DecrementUint(k string, n uint) (uint, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUintptr decrements an item of type uintptr by n, returning the new value.
+func (c *cache[V]) DecrementUintptr(k string, n uintptr) (uintptr, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uintptr)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uintptr", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUint8 decrements an item of type uint8 by n, returning the new value.
+func (c *cache[V]) DecrementUint8(k string, n uint8) (uint8, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint8)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint8", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUint16 decrements an item of type uint16 by n, returning the new value.
+func (c *cache[V]) DecrementUint16(k string, n uint16) (uint16, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint16)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint16", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUint32 decrements an item of type uint32 by n, returning the new value.
+func (c *cache[V]) DecrementUint32(k string, n uint32) (uint32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint32", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementUint64 decrements an item of type uint64 by n, returning the new value.
+func (c *cache[V]) DecrementUint64(k string, n uint64) (uint64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(uint64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a uint64", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementFloat32 decrements an item of type float32 by n, returning the new value.
+func (c *cache[V]) DecrementFloat32(k string, n float32) (float32, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(float32)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a float32", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
+
+// DecrementFloat64 decrements an item of type float64 by n, returning the new value.
+func (c *cache[V]) DecrementFloat64(k string, n float64) (float64, error) {
+	c.Lock()
+	defer c.Unlock()
+	it, found := c.items[k]
+	if !found || it.Expired() {
+		return 0, fmt.Errorf("Item %s not found", k)
+	}
+	rv, ok := any(it.Object).(float64)
+	if !ok {
+		return 0, fmt.Errorf("The value for %s is not a float64", k)
+	}
+	nv := rv - n
+	it.Object = any(nv).(V)
+	return nv, nil
+}
@@ -0,0 +1,96 @@
+package cache
+
+import "testing"
+
+func TestIncrementWithInt(t *testing.T) {
+	tc := New[int](0, 0)
+	tc.Set("tint", 1, NoExpiration)
+	err := tc.Increment("tint", 2)
+	if err != nil {
+		t.Error("Error incrementing:", err)
+	}
+	x, found := tc.Get("tint")
+	if !found {
+		t.Error("tint was not found")
+	}
+	if x != 3 {
+		t.Error("tint is not 3:", x)
+	}
+}
+
+func TestIncrementFloat(t *testing.T) {
+	tc := New[float64](0, 0)
+	tc.Set("float64", float64(1.5), NoExpiration)
+	err := tc.IncrementFloat("float64", 2)
+	if err != nil {
+		t.Error("Error incrementing:", err)
+	}
+	x, found := tc.Get("float64")
+	if !found {
+		t.Error("float64 was not found")
+	}
+	if x != 3.5 {
+		t.Error("float64 is not 3.5:", x)
+	}
+}
+
+func TestIncrementInt(t *testing.T) {
+	tc := New[int](0, 0)
+	tc.Set("int", 1, NoExpiration)
+	n, err := tc.IncrementInt("int", 2)
+	if err != nil {
+		t.Error("Error incrementing:", err)
+	}
+	if n != 3 {
+		t.Error("Returned number is not 3:", n)
+	}
+	x, _ := tc.Get("int")
+	if x != 3 {
+		t.Error("int is not 3:", x)
+	}
+}
+
+func TestIncrementOnMissingKey(t *testing.T) {
+	tc := New[int](0, 0)
+	err := tc.Increment("missing", 1)
+	if err == nil {
+		t.Error("Incrementing a missing key should have returned an error")
+	}
+}
+
+func TestIncrementOnNonNumericType(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("str", "not a number", NoExpiration)
+	err := tc.Increment("str", 1)
+	if err == nil {
+		t.Error("Incrementing a non-numeric type should have returned an error")
+	}
+}
+
+func TestDecrementWithInt(t *testing.T) {
+	tc := New[int](0, 0)
+	tc.Set("tint", 5, NoExpiration)
+	err := tc.Decrement("tint", 2)
+	if err != nil {
+		t.Error("Error decrementing:", err)
+	}
+	x, found := tc.Get("tint")
+	if !found {
+		t.Error("tint was not found")
+	}
+	if x != 3 {
+		t.Error("tint is not 3:", x)
+	}
+}
+
+func TestDecrementInt(t *testing.T) {
+	tc := New[int](0, 0)
+	tc.Set("int", 5, NoExpiration)
+	n, err := tc.DecrementInt("int", 2)
+	if err != nil {
+		t.Error("Error decrementing:", err)
+	}
+	if n != 3 {
+		t.Error("Returned number is not 3:", n)
+	}
+}
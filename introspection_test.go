@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetWithExpiration(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("a", "1", NoExpiration)
+	tc.Set("b", "2", 50*time.Millisecond)
+
+	x, exp, found := tc.GetWithExpiration("a")
+	if !found || x != "1" {
+		t.Error("a was not found or had the wrong value:", x, found)
+	}
+	if !exp.IsZero() {
+		t.Error("a should not have an expiration:", exp)
+	}
+
+	x, exp, found = tc.GetWithExpiration("b")
+	if !found || x != "2" {
+		t.Error("b was not found or had the wrong value:", x, found)
+	}
+	if exp.IsZero() {
+		t.Error("b should have an expiration")
+	}
+
+	_, _, found = tc.GetWithExpiration("c")
+	if found {
+		t.Error("GetWithExpiration found c when it should not exist")
+	}
+}
+
+func TestItems(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("a", "1", NoExpiration)
+	tc.Set("b", "2", 10*time.Millisecond)
+
+	<-time.After(20 * time.Millisecond)
+	items := tc.Items()
+	if _, found := items["a"]; !found {
+		t.Error("Items did not include a")
+	}
+	if _, found := items["b"]; found {
+		t.Error("Items included b even though it has expired")
+	}
+
+	items["a"] = item[string]{Object: "mutated", Expiration: 0}
+	x, _ := tc.Get("a")
+	if x != "1" {
+		t.Error("Mutating the map returned by Items affected the cache")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("a", "1", NoExpiration)
+	tc.Set("b", "2", NoExpiration)
+	tc.Flush()
+	if n := tc.ItemCount(); n != 0 {
+		t.Errorf("Item count is not 0 after Flush: %d", n)
+	}
+	_, found := tc.Get("a")
+	if found {
+		t.Error("a was found after Flush")
+	}
+}
@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCacheTimes sets items with a range of per-item TTLs and a janitor
+// running every millisecond, then checks that each item disappears on its
+// own schedule without any manual DeleteExpired call.
+func TestCacheTimes(t *testing.T) {
+	var found bool
+
+	tc := New[string](0, 1*time.Millisecond)
+	tc.Set("a", "a", 20*time.Millisecond)
+	tc.Set("b", "b", 50*time.Millisecond)
+	tc.Set("c", "c", 70*time.Millisecond)
+	tc.Set("d", "d", NoExpiration)
+
+	<-time.After(30 * time.Millisecond)
+	if _, found = tc.Get("a"); found {
+		t.Error("Found a when it should have been automatically deleted")
+	}
+	if _, found = tc.Get("b"); !found {
+		t.Error("Did not find b even though it should not have expired yet")
+	}
+	if _, found = tc.Get("c"); !found {
+		t.Error("Did not find c even though it should not have expired yet")
+	}
+
+	<-time.After(30 * time.Millisecond)
+	if _, found = tc.Get("b"); found {
+		t.Error("Found b when it should have been automatically deleted")
+	}
+	if _, found = tc.Get("c"); !found {
+		t.Error("Did not find c even though it should not have expired yet")
+	}
+
+	<-time.After(20 * time.Millisecond)
+	if _, found = tc.Get("c"); found {
+		t.Error("Found c when it should have been automatically deleted")
+	}
+	if _, found = tc.Get("d"); !found {
+		t.Error("Did not find d even though it was set to never expire")
+	}
+}
+
+// TestNewNoJanitor verifies that a cleanup interval of 0 preserves the old
+// behavior: expired items linger until DeleteExpired is called manually.
+func TestNewNoJanitor(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("foo", "bar", 20*time.Millisecond)
+	<-time.After(50 * time.Millisecond)
+	if n := tc.ItemCount(); n != 1 {
+		t.Errorf("Item count is not 1 even though no janitor was started: %d", n)
+	}
+}
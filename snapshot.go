@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Save writes the cache's items to w as a gob stream, including their
+// expiration timestamps, so a later Load can restore the cache's state
+// (for example after a restart) without re-populating it from the backing
+// store.
+func (c *cache[V]) Save(w io.Writer) (err error) {
+	enc := gob.NewEncoder(w)
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("Error registering item types with Gob library")
+		}
+	}()
+	c.RLock()
+	defer c.RUnlock()
+	for _, v := range c.items {
+		gob.Register(v.Object)
+	}
+	return enc.Encode(&c.items)
+}
+
+// SaveFile saves the cache's items to the given filename, creating the file
+// if it doesn't exist and overwriting it if it does.
+func (c *cache[V]) SaveFile(fname string) error {
+	fp, err := os.Create(fname)
+	if err != nil {
+		return err
+	}
+	err = c.Save(fp)
+	if err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+// Load adds the gob-serialized items from r to the cache. Existing,
+// unexpired items with the same keys are not overwritten.
+func (c *cache[V]) Load(r io.Reader) error {
+	items := map[string]*item[V]{}
+	dec := gob.NewDecoder(r)
+	err := dec.Decode(&items)
+	if err == nil {
+		c.Lock()
+		defer c.Unlock()
+		for k, v := range items {
+			ov, found := c.items[k]
+			if !found || ov.Expired() {
+				c.items[k] = v
+			}
+		}
+	}
+	return err
+}
+
+// LoadFile loads items from the given filename, as written by SaveFile.
+func (c *cache[V]) LoadFile(fname string) error {
+	fp, err := os.Open(fname)
+	if err != nil {
+		return err
+	}
+	err = c.Load(fp)
+	if err != nil {
+		fp.Close()
+		return err
+	}
+	return fp.Close()
+}
+
+// NewFrom returns a new cache populated with the given items and default
+// expiration duration. This is useful if you want to recover cache items
+// from a snapshot taken with Items() or Load after a restart, without
+// hammering the backing store while the cache is cold.
+func NewFrom[V any](defaultExpiration time.Duration, items map[string]item[V]) *Cache[V] {
+	c := newCache[V](defaultExpiration)
+	for k, v := range items {
+		v := v
+		c.items[k] = &v
+	}
+	return &Cache[V]{c}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSaveLoad(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("a", "1", NoExpiration)
+	tc.Set("b", "2", 5*time.Minute)
+
+	var buf bytes.Buffer
+	if err := tc.Save(&buf); err != nil {
+		t.Fatal("Couldn't save cache to buffer:", err)
+	}
+
+	oc := New[string](0, 0)
+	if err := oc.Load(&buf); err != nil {
+		t.Fatal("Couldn't load cache from buffer:", err)
+	}
+
+	a, found := oc.Get("a")
+	if !found || a != "1" {
+		t.Error("a was not loaded correctly:", a, found)
+	}
+	b, found := oc.Get("b")
+	if !found || b != "2" {
+		t.Error("b was not loaded correctly:", b, found)
+	}
+
+	if oc.items["a"].Expiration != 0 {
+		t.Error("a should not have an expiration after load")
+	}
+	if oc.items["b"].Expiration != tc.items["b"].Expiration {
+		t.Error("b's expiration did not survive the round trip")
+	}
+}
+
+func TestLoadDoesNotOverwriteUnexpired(t *testing.T) {
+	tc := New[string](0, 0)
+	tc.Set("a", "1", NoExpiration)
+
+	var buf bytes.Buffer
+	if err := tc.Save(&buf); err != nil {
+		t.Fatal("Couldn't save cache to buffer:", err)
+	}
+
+	oc := New[string](0, 0)
+	oc.Set("a", "already here", NoExpiration)
+	if err := oc.Load(&buf); err != nil {
+		t.Fatal("Couldn't load cache from buffer:", err)
+	}
+
+	a, _ := oc.Get("a")
+	if a != "already here" {
+		t.Error("Load overwrote an existing, unexpired item:", a)
+	}
+}